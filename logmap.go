@@ -2,23 +2,59 @@ package main
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"html/template"
+	"math"
 	"math/cmplx"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/mjibson/go-dsp/fft"
 	"github.com/wcharczuk/go-chart"
 )
 
 const (
-	Port        = ":3030"
-	Iterations  = 100
-	Start       = 0.1
-	DefaultRate = "3.5"
+	Port = ":3030"
+
+	DefaultIterations = 100
+	DefaultX0         = 0.1
+	DefaultRate       = "3.5"
+
+	// MaxIterations bounds how long an orbit a single request can ask for.
+	MaxIterations = 100000
+
+	// MaxTransient bounds how many warm-up iterations a single request can
+	// ask for. It is checked before, and in addition to, the point caps
+	// below, which also count transient iterations.
+	MaxTransient = 1000000
+
+	DefaultBifurcationSteps     = 500
+	DefaultBifurcationTransient = 200
+	DefaultBifurcationSamples   = 200
+
+	// MaxBifurcationPoints bounds steps*(samples+transient), and so also
+	// the steps*samples plotted points, so a single request can't
+	// blow up memory/render time, mirroring the series*values bounding
+	// pattern go-chart's own benchmark example uses.
+	MaxBifurcationPoints = 2000000
+
+	DefaultLyapunovTransient = 100
+	DefaultLyapunovSteps     = 500
+
+	// MaxLyapunovPoints bounds steps*(iterations+transient) for /lyapunov, for the same
+	// reason MaxBifurcationPoints bounds /bifurcation.
+	MaxLyapunovPoints = 2000000
+
+	// MaxSeriesPoints bounds len(rates)*(iterations+transient) for / and
+	// /chart, for the same reason MaxBifurcationPoints/MaxLyapunovPoints
+	// bound their own steps-many orbits: a `rates=` sweep calls logisticMap,
+	// frequencyTransform, and lyapunov once per rate, so the per-call
+	// iterations/transient caps alone don't bound the total work.
+	MaxSeriesPoints = 2000000
 )
 
 var tmpl *template.Template
@@ -57,53 +93,281 @@ func main() {
 		}
 	})
 
+	http.HandleFunc("/bifurcation", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getBifurcation(w, r)
+		default:
+			w.Header().Set("Allow", "GET")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	http.HandleFunc("/lyapunov", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			getLyapunov(w, r)
+		default:
+			w.Header().Set("Allow", "GET")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
 	http.ListenAndServe(Port, nil)
 }
 
-func get(w http.ResponseWriter, r *http.Request) {
-	// response is always JSON
-	w.Header().Set("Content-Type", "application/json")
+// bufferPool recycles the scratch buffers used to stream series encodings,
+// avoiding a fresh allocation per request.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
 
-	rate, err := getRate(r)
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	bufferPool.Put(buf)
+}
+
+// seriesResult is one rate's time and frequency series plus its Lyapunov
+// exponent, as produced for a / request.
+type seriesResult struct {
+	Rate      float64
+	Time      []float64
+	Frequency []float64
+	Lyapunov  float64
+}
+
+func get(w http.ResponseWriter, r *http.Request) {
+	params, err := getSeriesParams(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	timeSeries := logisticMap(rate)
-	frequencySeries := frequencyTransform(timeSeries)
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	results := make([]seriesResult, len(params.rates))
+	for i, rate := range params.rates {
+		timeSeries := logisticMap(rate, params.x0, params.iterations)
+		results[i] = seriesResult{
+			Rate:      rate,
+			Time:      timeSeries,
+			Frequency: frequencyTransform(timeSeries, params.window, params.scale),
+			Lyapunov:  lyapunov(rate, params.x0, params.iterations, params.transient),
+		}
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	switch format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		writeSeriesJSON(buf, results)
+	case "ndjson":
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		writeSeriesNDJSON(buf, results)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		writeSeriesCSV(buf, results)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Write(buf.Bytes())
+}
 
-	output, err := json.Marshal(struct {
-		Time      [Iterations]float64 `json:"time"`
-		Frequency [Iterations]float64 `json:"frequency"`
-	}{timeSeries, frequencySeries})
+// appendFloat renders f without allocating by reusing scratch, a
+// caller-owned buffer at least 32 bytes long.
+func appendFloat(buf *bytes.Buffer, scratch []byte, f float64) {
+	buf.Write(strconv.AppendFloat(scratch[:0], f, 'f', -1, 64))
+}
 
-	// return server error if marshaling fails
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(os.Stderr, "%s\n", err)
+// writeSeriesJSON streams the {"time":[...],"frequency":[...],"lyapunov":...}
+// shape when a single rate is requested, without marshaling a struct through
+// reflection. A `rates=` sweep instead streams
+// {"series":[{"rate":...,"time":[...],"frequency":[...],"lyapunov":...},...]}.
+func writeSeriesJSON(buf *bytes.Buffer, results []seriesResult) {
+	var scratch [32]byte
+
+	if len(results) == 1 {
+		buf.WriteByte('{')
+		writeOneSeriesJSON(buf, &scratch, results[0])
+		buf.WriteByte('}')
 		return
 	}
 
-	w.Write(output)
+	buf.WriteString(`{"series":[`)
+	for i, result := range results {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"rate":`)
+		appendFloat(buf, scratch[:], result.Rate)
+		buf.WriteByte(',')
+		writeOneSeriesJSON(buf, &scratch, result)
+		buf.WriteByte('}')
+	}
+	buf.WriteString(`]}`)
+}
+
+func writeOneSeriesJSON(buf *bytes.Buffer, scratch *[32]byte, result seriesResult) {
+	buf.WriteString(`"time":[`)
+	for i, v := range result.Time {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		appendFloat(buf, scratch[:], v)
+	}
+	buf.WriteString(`],"frequency":[`)
+	for i, v := range result.Frequency {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		appendFloat(buf, scratch[:], v)
+	}
+	buf.WriteString(`],"lyapunov":`)
+	appendFloat(buf, scratch[:], result.Lyapunov)
+}
+
+// writeSeriesNDJSON streams one object per sample, so clients can process
+// the series without buffering the whole response. Time and frequency are
+// streamed as independent sections, since windowing/one-sided truncation
+// (see frequencyTransform) means they generally differ in length: a time
+// sample is `{"t":...,"x":...}` and a frequency bin is `{"bin":...,"f":...}`,
+// each with a "rate" field added when `rates=` sweeps more than one series.
+func writeSeriesNDJSON(buf *bytes.Buffer, results []seriesResult) {
+	var scratch [32]byte
+	multi := len(results) > 1
+
+	for _, result := range results {
+		for i, x := range result.Time {
+			if multi {
+				buf.WriteString(`{"rate":`)
+				appendFloat(buf, scratch[:], result.Rate)
+				buf.WriteString(`,"t":`)
+			} else {
+				buf.WriteString(`{"t":`)
+			}
+			buf.WriteString(strconv.Itoa(i))
+			buf.WriteString(`,"x":`)
+			appendFloat(buf, scratch[:], x)
+			buf.WriteString("}\n")
+		}
+		for i, f := range result.Frequency {
+			if multi {
+				buf.WriteString(`{"rate":`)
+				appendFloat(buf, scratch[:], result.Rate)
+				buf.WriteString(`,"bin":`)
+			} else {
+				buf.WriteString(`{"bin":`)
+			}
+			buf.WriteString(strconv.Itoa(i))
+			buf.WriteString(`,"f":`)
+			appendFloat(buf, scratch[:], f)
+			buf.WriteString("}\n")
+		}
+	}
+}
+
+// writeSeriesCSV streams a header followed by one row per sample, as two
+// sections (time, then frequency), since windowing/one-sided truncation
+// (see frequencyTransform) means they generally differ in length. A
+// `rates=` sweep adds a leading rate column to both sections.
+func writeSeriesCSV(buf *bytes.Buffer, results []seriesResult) {
+	var scratch [32]byte
+	multi := len(results) > 1
+
+	buf.WriteString("# time\n")
+	if multi {
+		buf.WriteString("rate,t,x\n")
+	} else {
+		buf.WriteString("t,x\n")
+	}
+	for _, result := range results {
+		for i, x := range result.Time {
+			if multi {
+				appendFloat(buf, scratch[:], result.Rate)
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(i))
+			buf.WriteByte(',')
+			appendFloat(buf, scratch[:], x)
+			buf.WriteByte('\n')
+		}
+	}
+
+	buf.WriteString("# frequency\n")
+	if multi {
+		buf.WriteString("rate,bin,f\n")
+	} else {
+		buf.WriteString("bin,f\n")
+	}
+	for _, result := range results {
+		for i, f := range result.Frequency {
+			if multi {
+				appendFloat(buf, scratch[:], result.Rate)
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(i))
+			buf.WriteByte(',')
+			appendFloat(buf, scratch[:], f)
+			buf.WriteByte('\n')
+		}
+	}
 }
 
 func getChart(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 
-	rate, err := getRate(r)
+	params, err := getSeriesParams(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	timeSeries := logisticMap(rate)
-	timeYSeries := timeSeries[:]
+	timeStep := 1.0 / float64(params.iterations)
+
+	var timeChartSeries []chart.Series
+	var frequencyChartSeries []chart.Series
+
+	for _, rate := range params.rates {
+		timeSeries := logisticMap(rate, params.x0, params.iterations)
+
+		timeXSeries := make([]float64, len(timeSeries))
+		for i := range timeXSeries {
+			timeXSeries[i] = float64(i) * timeStep
+		}
+		timeChartSeries = append(timeChartSeries, chart.ContinuousSeries{
+			XValues: timeXSeries,
+			YValues: timeSeries,
+		})
+
+		// horizontal line annotating the orbit's Lyapunov exponent, so
+		// chaotic (λ > 0) and periodic (λ < 0) rates are visible at a glance
+		lambda := lyapunov(rate, params.x0, params.iterations, params.transient)
+		timeChartSeries = append(timeChartSeries, chart.ContinuousSeries{
+			Name:    fmt.Sprintf("λ(%.2f) = %.3f", rate, lambda),
+			XValues: []float64{timeXSeries[0], timeXSeries[len(timeXSeries)-1]},
+			YValues: []float64{lambda, lambda},
+			Style: chart.Style{
+				StrokeDashArray: []float64{5, 5},
+			},
+		})
 
-	timeXSeries := make([]float64, Iterations, Iterations)
-	timeStep := 1.0 / Iterations
-	for i := 0; i < Iterations; i++ {
-		timeXSeries[i] = float64(i) * timeStep
+		frequencySeries := frequencyTransform(timeSeries, params.window, params.scale)
+		frequencyChartSeries = append(frequencyChartSeries, chart.ContinuousSeries{
+			XValues: frequencyBins(len(timeSeries)),
+			YValues: frequencySeries,
+		})
 	}
 
 	timeChart := chart.Chart{
@@ -124,21 +388,7 @@ func getChart(w http.ResponseWriter, r *http.Request) {
 				Show: true,
 			},
 		},
-		Series: []chart.Series{
-			chart.ContinuousSeries{
-				XValues: timeXSeries,
-				YValues: timeYSeries,
-			},
-		},
-	}
-
-	frequencySeries := frequencyTransform(timeSeries)
-	frequencyYSeries := frequencySeries[:]
-
-	frequencyXSeries := make([]float64, Iterations, Iterations)
-	frequencyStep := 0.5 / Iterations
-	for i := 0; i < Iterations; i++ {
-		frequencyXSeries[i] = float64(i) * frequencyStep
+		Series: timeChartSeries,
 	}
 
 	frequencyChart := chart.Chart{
@@ -159,21 +409,21 @@ func getChart(w http.ResponseWriter, r *http.Request) {
 				Show: true,
 			},
 		},
-		Series: []chart.Series{
-			chart.ContinuousSeries{
-				XValues: frequencyXSeries,
-				YValues: frequencyYSeries,
-			},
-		},
+		Series: frequencyChartSeries,
 	}
 
 	var buf bytes.Buffer
 	timeChart.Render(chart.SVG, &buf)
 	frequencyChart.Render(chart.SVG, &buf)
 
+	rateLabels := make([]string, len(params.rates))
+	for i, rate := range params.rates {
+		rateLabels[i] = fmt.Sprintf("%.2f", rate)
+	}
+
 	err = tmpl.Execute(w, context{
 		Body: template.HTML(buf.String()),
-		Rate: fmt.Sprintf("%.2f", rate),
+		Rate: strings.Join(rateLabels, ", "),
 	})
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -182,45 +432,480 @@ func getChart(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getRate(r *http.Request) (float64, error) {
-	// extract "rate" param
-	rateParam := r.URL.Query().Get("rate")
-	if rateParam == "" {
-		rateParam = DefaultRate
+// bifurcationParams holds the parsed query parameters for /bifurcation.
+type bifurcationParams struct {
+	rmin, rmax         float64
+	steps              int
+	transient, samples int
+}
+
+func getBifurcationParams(r *http.Request) (params bifurcationParams, err error) {
+	query := r.URL.Query()
+
+	if params.rmin, err = queryFloat(query, "rmin", 0); err != nil {
+		return
+	}
+	if params.rmax, err = queryFloat(query, "rmax", 4); err != nil {
+		return
+	}
+	if params.steps, err = queryInt(query, "steps", DefaultBifurcationSteps); err != nil {
+		return
+	}
+	if params.transient, err = queryInt(query, "transient", DefaultBifurcationTransient); err != nil {
+		return
+	}
+	if params.samples, err = queryInt(query, "samples", DefaultBifurcationSamples); err != nil {
+		return
 	}
 
-	// parse "rate" param or return client error
-	return strconv.ParseFloat(rateParam, 64)
+	if params.steps <= 0 || params.samples <= 0 || params.transient < 0 {
+		err = fmt.Errorf("steps and samples must be positive, transient must be non-negative")
+		return
+	}
+	if params.transient > MaxTransient {
+		err = fmt.Errorf("transient must not exceed %d", MaxTransient)
+		return
+	}
+	// bound total logisticOrbit work (steps calls, each transient+samples
+	// iterations), not just steps*samples, so a huge transient with tiny
+	// samples can't sneak past the cap.
+	if params.steps*(params.samples+params.transient) > MaxBifurcationPoints {
+		err = fmt.Errorf("steps*(samples+transient) exceeds the %d point cap", MaxBifurcationPoints)
+		return
+	}
+
+	return
+}
+
+func queryFloat(query url.Values, name string, fallback float64) (float64, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+func queryInt(query url.Values, name string, fallback int) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// getBifurcation renders the classic logistic-map bifurcation diagram: for
+// each of steps rates sampled evenly across [rmin, rmax), the orbit is iterated past a transient
+// and the remaining samples are plotted as a scatter of x versus r.
+func getBifurcation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+
+	params, err := getBifurcationParams(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rSeries := make([]float64, 0, params.steps*params.samples)
+	xSeries := make([]float64, 0, params.steps*params.samples)
+
+	step := (params.rmax - params.rmin) / float64(params.steps)
+	for i := 0; i < params.steps; i++ {
+		rate := params.rmin + float64(i)*step
+		orbit := logisticOrbit(rate, DefaultX0, params.transient, params.samples)
+		for _, x := range orbit {
+			rSeries = append(rSeries, rate)
+			xSeries = append(xSeries, x)
+		}
+	}
+
+	bifurcationChart := chart.Chart{
+		Width:  800,
+		Height: 600,
+		XAxis: chart.XAxis{
+			Style: chart.Style{
+				Show: true,
+			},
+			Name:      "r",
+			NameStyle: chart.Style{Show: true},
+		},
+		YAxis: chart.YAxis{
+			Style: chart.Style{
+				Show: true,
+			},
+			Name:      "x",
+			NameStyle: chart.Style{Show: true},
+		},
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				XValues: rSeries,
+				YValues: xSeries,
+				Style: chart.Style{
+					StrokeWidth: chart.Disabled,
+					DotWidth:    1,
+				},
+			},
+		},
+	}
+
+	if err := bifurcationChart.Render(chart.SVG, w); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return
+	}
+}
+
+// window is an FFT window function applied to a time series before it is
+// transformed, to reduce spectral leakage.
+type window int
+
+const (
+	WindowRectangular window = iota
+	WindowHann
+	WindowHamming
+	WindowBlackman
+)
+
+func parseWindow(s string) (window, error) {
+	switch s {
+	case "", "rectangular":
+		return WindowRectangular, nil
+	case "hann":
+		return WindowHann, nil
+	case "hamming":
+		return WindowHamming, nil
+	case "blackman":
+		return WindowBlackman, nil
+	default:
+		return 0, fmt.Errorf("unknown window %q", s)
+	}
+}
+
+// applyWindow returns a copy of series with the window function applied.
+func applyWindow(series []float64, w window) []float64 {
+	n := len(series)
+	output := make([]float64, n)
+	if w == WindowRectangular || n < 2 {
+		copy(output, series)
+		return output
+	}
+
+	for i, v := range series {
+		phase := 2 * math.Pi * float64(i) / float64(n-1)
+		switch w {
+		case WindowHann:
+			output[i] = v * 0.5 * (1 - math.Cos(phase))
+		case WindowHamming:
+			output[i] = v * (0.54 - 0.46*math.Cos(phase))
+		case WindowBlackman:
+			output[i] = v * (0.42 - 0.5*math.Cos(phase) + 0.08*math.Cos(2*phase))
+		}
+	}
+	return output
+}
+
+// scale is the output scale of a magnitude spectrum.
+type scale int
+
+const (
+	ScaleLinear scale = iota
+	ScaleDB
+)
+
+func parseScale(s string) (scale, error) {
+	switch s {
+	case "", "linear":
+		return ScaleLinear, nil
+	case "db":
+		return ScaleDB, nil
+	default:
+		return 0, fmt.Errorf("unknown scale %q", s)
+	}
+}
+
+// seriesParams holds the parsed query parameters shared by / and /chart:
+// the rate or rates to sweep, the orbit length, the initial condition, the
+// FFT window/scale to apply, and the transient discarded before computing
+// the Lyapunov exponent.
+type seriesParams struct {
+	rates      []float64
+	iterations int
+	x0         float64
+	window     window
+	scale      scale
+	transient  int
+}
+
+func getSeriesParams(r *http.Request) (params seriesParams, err error) {
+	query := r.URL.Query()
+
+	if ratesParam := query.Get("rates"); ratesParam != "" {
+		parts := strings.Split(ratesParam, ",")
+		params.rates = make([]float64, len(parts))
+		for i, part := range parts {
+			if params.rates[i], err = strconv.ParseFloat(strings.TrimSpace(part), 64); err != nil {
+				return
+			}
+		}
+	} else {
+		rateParam := query.Get("rate")
+		if rateParam == "" {
+			rateParam = DefaultRate
+		}
+		var rate float64
+		if rate, err = strconv.ParseFloat(rateParam, 64); err != nil {
+			return
+		}
+		params.rates = []float64{rate}
+	}
+
+	if params.iterations, err = queryInt(query, "iterations", DefaultIterations); err != nil {
+		return
+	}
+	if params.iterations <= 0 || params.iterations > MaxIterations {
+		err = fmt.Errorf("iterations must be between 1 and %d", MaxIterations)
+		return
+	}
+
+	if params.x0, err = queryFloat(query, "x0", DefaultX0); err != nil {
+		return
+	}
+	if params.x0 <= 0 || params.x0 >= 1 {
+		err = fmt.Errorf("x0 must be strictly between 0 and 1")
+		return
+	}
+
+	if params.window, err = parseWindow(query.Get("window")); err != nil {
+		return
+	}
+	if params.scale, err = parseScale(query.Get("scale")); err != nil {
+		return
+	}
+
+	if params.transient, err = queryInt(query, "transient", DefaultLyapunovTransient); err != nil {
+		return
+	}
+	if params.transient < 0 || params.transient > MaxTransient {
+		err = fmt.Errorf("transient must be between 0 and %d", MaxTransient)
+		return
+	}
+	// bound total per-rate work (logisticMap, frequencyTransform, and
+	// lyapunov's second orbit pass), not just the per-call iterations/
+	// transient caps, so a `rates=` sweep can't multiply them out unbounded.
+	if len(params.rates)*(params.iterations+params.transient) > MaxSeriesPoints {
+		err = fmt.Errorf("len(rates)*(iterations+transient) exceeds the %d point cap", MaxSeriesPoints)
+		return
+	}
+
+	return
 }
 
 // generates the logistic map series for the given growth rate
-func logisticMap(rate float64) (series [Iterations]float64) {
-	// 0 < x < 1 | x(n+1) = rate * x(n) * (1 - x(n))
-	x := Start
-	for i := 0; i < Iterations; i++ {
+func logisticMap(rate, x0 float64, iterations int) []float64 {
+	return logisticOrbit(rate, x0, 0, iterations)
+}
+
+// logisticOrbit iterates x(n+1) = rate * x(n) * (1 - x(n)) starting at x0,
+// discards the first `transient` iterations to let the orbit settle, then
+// returns the next `samples` values.
+func logisticOrbit(rate, x0 float64, transient, samples int) []float64 {
+	x := x0
+	for i := 0; i < transient; i++ {
+		x = rate * x * (1 - x)
+	}
+
+	series := make([]float64, samples)
+	for i := 0; i < samples; i++ {
 		x = rate * x * (1 - x)
 		series[i] = x
 	}
+	return series
+}
+
+// lyapunov estimates the largest Lyapunov exponent of the logistic map at
+// the given rate and initial condition: λ = (1/N) · Σ ln|rate·(1−2·x_n)|
+// over the orbit's N samples after discarding transient warm-up iterations.
+// Positive λ indicates chaos, negative a stable periodic orbit. Terms where
+// the derivative is ~0 (x_n ≈ 0.5) are skipped to avoid log(0).
+func lyapunov(rate, x0 float64, iterations, transient int) float64 {
+	const epsilon = 1e-12
+
+	orbit := logisticOrbit(rate, x0, transient, iterations)
+
+	var sum float64
+	var count int
+	for _, x := range orbit {
+		derivative := math.Abs(rate * (1 - 2*x))
+		if derivative < epsilon {
+			continue
+		}
+		sum += math.Log(derivative)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// lyapunovCurveParams holds the parsed query parameters for /lyapunov.
+type lyapunovCurveParams struct {
+	rmin, rmax            float64
+	steps                 int
+	iterations, transient int
+}
+
+func getLyapunovCurveParams(r *http.Request) (params lyapunovCurveParams, err error) {
+	query := r.URL.Query()
+
+	if params.rmin, err = queryFloat(query, "rmin", 0); err != nil {
+		return
+	}
+	if params.rmax, err = queryFloat(query, "rmax", 4); err != nil {
+		return
+	}
+	if params.steps, err = queryInt(query, "steps", DefaultLyapunovSteps); err != nil {
+		return
+	}
+	if params.iterations, err = queryInt(query, "iterations", DefaultIterations); err != nil {
+		return
+	}
+	if params.transient, err = queryInt(query, "transient", DefaultLyapunovTransient); err != nil {
+		return
+	}
+
+	if params.steps <= 0 || params.iterations <= 0 || params.transient < 0 {
+		err = fmt.Errorf("steps and iterations must be positive, transient must be non-negative")
+		return
+	}
+	if params.iterations > MaxIterations {
+		err = fmt.Errorf("iterations must not exceed %d", MaxIterations)
+		return
+	}
+	if params.transient > MaxTransient {
+		err = fmt.Errorf("transient must not exceed %d", MaxTransient)
+		return
+	}
+	// bound total logisticOrbit work (steps calls, each transient+iterations
+	// iterations), not just steps*iterations, so a huge transient with tiny
+	// iterations can't sneak past the cap.
+	if params.steps*(params.iterations+params.transient) > MaxLyapunovPoints {
+		err = fmt.Errorf("steps*(iterations+transient) exceeds the %d point cap", MaxLyapunovPoints)
+		return
+	}
+
 	return
 }
 
-// transforms an array of amplitude values over time to an array of amplitude
-// values, sorted by frequency
-func frequencyTransform(series [Iterations]float64) (output [Iterations]float64) {
-	// convert array of real numbers to array of complex numbers with no
-	// imaginary component
-	input := make([]complex128, Iterations, Iterations)
-	for i := range series {
-		input[i] = cmplx.Rect(series[i], 0)
+// getLyapunov returns λ(r) at steps rates sampled evenly across [rmin, rmax)
+// (rmax itself is not sampled) so chaotic bands can be
+// visually correlated against the /bifurcation diagram.
+func getLyapunov(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	params, err := getLyapunovCurveParams(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
 
-	// outsource the actual transform to fft library
+	rSeries := make([]float64, params.steps)
+	lambdaSeries := make([]float64, params.steps)
+
+	step := (params.rmax - params.rmin) / float64(params.steps)
+	for i := 0; i < params.steps; i++ {
+		rate := params.rmin + float64(i)*step
+		rSeries[i] = rate
+		lambdaSeries[i] = lyapunov(rate, DefaultX0, params.iterations, params.transient)
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	var scratch [32]byte
+	buf.WriteString(`{"r":[`)
+	for i, v := range rSeries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		appendFloat(buf, scratch[:], v)
+	}
+	buf.WriteString(`],"lyapunov":[`)
+	for i, v := range lambdaSeries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		appendFloat(buf, scratch[:], v)
+	}
+	buf.WriteString(`]}`)
+
+	w.Write(buf.Bytes())
+}
+
+// frequencyTransform computes the one-sided magnitude spectrum of series: w
+// is applied to reduce spectral leakage, the windowed series is zero-padded
+// up to the next power of two (go-dsp's FFT requires it), and only the first
+// N/2+1 bins are kept since the rest mirror them for a real-valued input.
+// sc optionally converts the result to dB relative to the peak magnitude.
+func frequencyTransform(series []float64, w window, sc scale) []float64 {
+	input := padToPowerOfTwo(applyWindow(series, w))
+	paddedN := len(input)
+
 	frequencies := fft.FFT(input)
 
-	// convert array of complex numbers to array of real numbers by stripping the
-	// imaginary component
-	for i := range frequencies {
-		output[i] = real(frequencies[i])
+	oneSidedLen := paddedN/2 + 1
+	magnitudes := make([]float64, oneSidedLen)
+	for i := range magnitudes {
+		magnitudes[i] = cmplx.Abs(frequencies[i])
 	}
-	return
+
+	if sc == ScaleDB {
+		toDB(magnitudes)
+	}
+	return magnitudes
+}
+
+// toDB converts magnitudes to dB relative to their peak, in place. A small
+// epsilon keeps log10 finite for bins at or near zero.
+func toDB(magnitudes []float64) {
+	const epsilon = 1e-12
+
+	peak := epsilon
+	for _, m := range magnitudes {
+		if m > peak {
+			peak = m
+		}
+	}
+	for i, m := range magnitudes {
+		magnitudes[i] = 20 * math.Log10((m+epsilon)/peak)
+	}
+}
+
+// frequencyBins returns the normalized frequency (0..0.5 cycles/sample) of
+// each bin frequencyTransform would return for a series of length seriesLen.
+func frequencyBins(seriesLen int) []float64 {
+	paddedN := nextPowerOfTwo(seriesLen)
+	bins := make([]float64, paddedN/2+1)
+	for i := range bins {
+		bins[i] = float64(i) / float64(paddedN)
+	}
+	return bins
+}
+
+// padToPowerOfTwo converts series to complex128 with no imaginary component,
+// zero-padded up to the next power of two.
+func padToPowerOfTwo(series []float64) []complex128 {
+	input := make([]complex128, nextPowerOfTwo(len(series)))
+	for i, v := range series {
+		input[i] = cmplx.Rect(v, 0)
+	}
+	return input
+}
+
+// nextPowerOfTwo returns the smallest power of two greater than or equal to n.
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
 }